@@ -0,0 +1,187 @@
+package main
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	ErrorInvalidInt      = errors.New("value is not a valid int")
+	ErrorInvalidUint     = errors.New("value is not a valid uint")
+	ErrorInvalidFloat    = errors.New("value is not a valid float")
+	ErrorInvalidBool     = errors.New("value is not a valid bool")
+	ErrorInvalidDuration = errors.New("value is not a valid duration")
+)
+
+// GetInt returns the value of key within section parsed as an int64.
+func (p *INIParser) GetInt(section, key string) (int64, error) {
+	raw, err := p.Get(section, key)
+	if err != nil {
+		return 0, err
+	}
+
+	n, err := strconv.ParseInt(raw, 10, 64)
+	if err != nil {
+		return 0, ErrorInvalidInt
+	}
+
+	return n, nil
+}
+
+// GetUint returns the value of key within section parsed as a uint64.
+func (p *INIParser) GetUint(section, key string) (uint64, error) {
+	raw, err := p.Get(section, key)
+	if err != nil {
+		return 0, err
+	}
+
+	n, err := strconv.ParseUint(raw, 10, 64)
+	if err != nil {
+		return 0, ErrorInvalidUint
+	}
+
+	return n, nil
+}
+
+// GetFloat returns the value of key within section parsed as a float64.
+func (p *INIParser) GetFloat(section, key string) (float64, error) {
+	raw, err := p.Get(section, key)
+	if err != nil {
+		return 0, err
+	}
+
+	f, err := strconv.ParseFloat(raw, 64)
+	if err != nil {
+		return 0, ErrorInvalidFloat
+	}
+
+	return f, nil
+}
+
+// GetBool returns the value of key within section parsed as a bool.
+// Besides strconv.ParseBool's "true/false/1/0", it also accepts
+// "yes/no" and "on/off", case-insensitively.
+func (p *INIParser) GetBool(section, key string) (bool, error) {
+	raw, err := p.Get(section, key)
+	if err != nil {
+		return false, err
+	}
+
+	switch strings.ToLower(raw) {
+	case "yes", "on":
+		return true, nil
+	case "no", "off":
+		return false, nil
+	}
+
+	b, err := strconv.ParseBool(raw)
+	if err != nil {
+		return false, ErrorInvalidBool
+	}
+
+	return b, nil
+}
+
+// GetDuration returns the value of key within section parsed with
+// time.ParseDuration.
+func (p *INIParser) GetDuration(section, key string) (time.Duration, error) {
+	raw, err := p.Get(section, key)
+	if err != nil {
+		return 0, err
+	}
+
+	d, err := time.ParseDuration(raw)
+	if err != nil {
+		return 0, ErrorInvalidDuration
+	}
+
+	return d, nil
+}
+
+// GetStringSlice returns the value of key within section split on sep,
+// with surrounding whitespace trimmed from each element.
+func (p *INIParser) GetStringSlice(section, key, sep string) ([]string, error) {
+	raw, err := p.Get(section, key)
+	if err != nil {
+		return nil, err
+	}
+
+	if raw == "" {
+		return nil, nil
+	}
+
+	parts := strings.Split(raw, sep)
+	for i, part := range parts {
+		parts[i] = strings.TrimSpace(part)
+	}
+
+	return parts, nil
+}
+
+// MustInt returns the int64 value of key within section, or def if the
+// section/key is missing or the value can't be parsed.
+func (p *INIParser) MustInt(section, key string, def int64) int64 {
+	v, err := p.GetInt(section, key)
+	if err != nil {
+		return def
+	}
+
+	return v
+}
+
+// MustUint returns the uint64 value of key within section, or def if
+// the section/key is missing or the value can't be parsed.
+func (p *INIParser) MustUint(section, key string, def uint64) uint64 {
+	v, err := p.GetUint(section, key)
+	if err != nil {
+		return def
+	}
+
+	return v
+}
+
+// MustFloat returns the float64 value of key within section, or def if
+// the section/key is missing or the value can't be parsed.
+func (p *INIParser) MustFloat(section, key string, def float64) float64 {
+	v, err := p.GetFloat(section, key)
+	if err != nil {
+		return def
+	}
+
+	return v
+}
+
+// MustBool returns the bool value of key within section, or def if the
+// section/key is missing or the value can't be parsed.
+func (p *INIParser) MustBool(section, key string, def bool) bool {
+	v, err := p.GetBool(section, key)
+	if err != nil {
+		return def
+	}
+
+	return v
+}
+
+// MustDuration returns the time.Duration value of key within section,
+// or def if the section/key is missing or the value can't be parsed.
+func (p *INIParser) MustDuration(section, key string, def time.Duration) time.Duration {
+	v, err := p.GetDuration(section, key)
+	if err != nil {
+		return def
+	}
+
+	return v
+}
+
+// MustStringSlice returns the value of key within section split on
+// sep, or def if the section/key is missing.
+func (p *INIParser) MustStringSlice(section, key, sep string, def []string) []string {
+	v, err := p.GetStringSlice(section, key, sep)
+	if err != nil {
+		return def
+	}
+
+	return v
+}
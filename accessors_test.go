@@ -0,0 +1,233 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+var accessorsData = `
+[server]
+port = 8080
+ratio = 0.5
+enabled = yes
+disabled = off
+timeout = 5s
+tags = a, b, c
+bad_int = nope
+bad_bool = nope`
+
+func TestGetInt(t *testing.T) {
+	ini := NewINIParser()
+	if err := ini.LoadFromString(accessorsData); err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		key     string
+		want    int64
+		wantErr error
+	}{
+		{"valid", "port", 8080, nil},
+		{"missing key", "missing", 0, ErrorKeyName},
+		{"invalid", "bad_int", 0, ErrorInvalidInt},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ini.GetInt("server", tt.key)
+			if err != tt.wantErr {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+
+			if err == nil && got != tt.want {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestGetUint(t *testing.T) {
+	ini := NewINIParser()
+	if err := ini.LoadFromString(accessorsData); err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		key     string
+		want    uint64
+		wantErr error
+	}{
+		{"valid", "port", 8080, nil},
+		{"missing section", "port", 0, ErrorSectionNotFound},
+		{"invalid", "bad_int", 0, ErrorInvalidUint},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			section := "server"
+			if tt.name == "missing section" {
+				section = "missing"
+			}
+
+			got, err := ini.GetUint(section, tt.key)
+			if err != tt.wantErr {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+
+			if err == nil && got != tt.want {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestGetFloat(t *testing.T) {
+	ini := NewINIParser()
+	if err := ini.LoadFromString(accessorsData); err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		key     string
+		want    float64
+		wantErr error
+	}{
+		{"valid", "ratio", 0.5, nil},
+		{"invalid", "bad_int", 0, ErrorInvalidFloat},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ini.GetFloat("server", tt.key)
+			if err != tt.wantErr {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+
+			if err == nil && got != tt.want {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestGetBool(t *testing.T) {
+	ini := NewINIParser()
+	if err := ini.LoadFromString(accessorsData); err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		key     string
+		want    bool
+		wantErr error
+	}{
+		{"yes", "enabled", true, nil},
+		{"off", "disabled", false, nil},
+		{"invalid", "bad_bool", false, ErrorInvalidBool},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ini.GetBool("server", tt.key)
+			if err != tt.wantErr {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+
+			if err == nil && got != tt.want {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestGetDuration(t *testing.T) {
+	ini := NewINIParser()
+	if err := ini.LoadFromString(accessorsData); err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		key     string
+		want    time.Duration
+		wantErr error
+	}{
+		{"valid", "timeout", 5 * time.Second, nil},
+		{"invalid", "bad_int", 0, ErrorInvalidDuration},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ini.GetDuration("server", tt.key)
+			if err != tt.wantErr {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+
+			if err == nil && got != tt.want {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestGetStringSlice(t *testing.T) {
+	ini := NewINIParser()
+	if err := ini.LoadFromString(accessorsData); err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+
+	got, err := ini.GetStringSlice("server", "tags", ",")
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+
+	want := []string{"a", "b", "c"}
+	if len(got) != len(want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("expected %v, got %v", want, got)
+		}
+	}
+}
+
+func TestMustGetters(t *testing.T) {
+	ini := NewINIParser()
+	if err := ini.LoadFromString(accessorsData); err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+
+	if got := ini.MustInt("server", "port", 1); got != 8080 {
+		t.Errorf("expected 8080, got %v", got)
+	}
+
+	if got := ini.MustInt("server", "missing", 42); got != 42 {
+		t.Errorf("expected default 42, got %v", got)
+	}
+
+	if got := ini.MustInt("server", "bad_int", 42); got != 42 {
+		t.Errorf("expected default 42 on parse failure, got %v", got)
+	}
+
+	if got := ini.MustBool("server", "enabled", false); got != true {
+		t.Errorf("expected true, got %v", got)
+	}
+
+	if got := ini.MustBool("server", "missing", true); got != true {
+		t.Errorf("expected default true, got %v", got)
+	}
+
+	if got := ini.MustDuration("server", "timeout", time.Minute); got != 5*time.Second {
+		t.Errorf("expected 5s, got %v", got)
+	}
+
+	if got := ini.MustDuration("server", "missing", time.Minute); got != time.Minute {
+		t.Errorf("expected default 1m, got %v", got)
+	}
+}
@@ -0,0 +1,158 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+var (
+	ErrorIncludeNotFound = errors.New("include target not found")
+	ErrorIncludeCycle    = errors.New("recursive include detected")
+)
+
+const includeDirectivePrefix = "!include "
+
+// parseDocument parses data into an ordered sequence of lines, the same
+// way LoadFromStringWithOptions does, additionally resolving
+// "!include path" and "include = path" directives against baseDir.
+// visiting holds the absolute paths currently being included, so that a
+// file that (transitively) includes itself is reported as
+// ErrorIncludeCycle instead of recursing forever.
+func (p *INIParser) parseDocument(data string, opts LoadOptions, baseDir string, visiting map[string]bool) ([]line, error) {
+	var lines []line
+
+	currentSection := opts.DefaultSection
+	seenKeys := map[string]map[string]bool{}
+
+	if currentSection != "" {
+		seenKeys[foldSection(opts, currentSection)] = map[string]bool{}
+	}
+
+	for _, raw := range strings.Split(data, "\n") {
+		trimmed := strings.TrimSpace(raw)
+
+		switch {
+		case trimmed == "":
+			lines = append(lines, line{kind: lineBlank, raw: raw})
+
+		case strings.HasPrefix(trimmed, includeDirectivePrefix):
+			target := strings.TrimSpace(strings.TrimPrefix(trimmed, includeDirectivePrefix))
+
+			included, err := p.resolveInclude(target, baseDir, opts, visiting)
+			if err != nil {
+				return nil, err
+			}
+
+			lines = append(lines, included...)
+			mergeSeenKeys(seenKeys, included, opts)
+
+		case strings.HasPrefix(trimmed, ";") || strings.HasPrefix(trimmed, "#"):
+			lines = append(lines, line{kind: lineComment, raw: raw})
+
+		case strings.HasPrefix(trimmed, "["):
+			if !strings.HasSuffix(trimmed, "]") {
+				return nil, ErrorInvalidFormat
+			}
+
+			name := strings.TrimSpace(trimmed[1 : len(trimmed)-1])
+			if name == "" {
+				return nil, ErrorSectionNameEmpty
+			}
+
+			currentSection = name
+			if _, ok := seenKeys[foldSection(opts, currentSection)]; !ok {
+				seenKeys[foldSection(opts, currentSection)] = map[string]bool{}
+			}
+
+			lines = append(lines, line{kind: lineSection, raw: raw, section: name})
+
+		case strings.Contains(trimmed, "="):
+			parts := strings.SplitN(trimmed, "=", 2)
+			key := strings.TrimSpace(parts[0])
+			value := strings.TrimSpace(parts[1])
+
+			if key == "" {
+				return nil, ErrorInvalidKeyFormat
+			}
+
+			if key == "include" {
+				included, err := p.resolveInclude(value, baseDir, opts, visiting)
+				if err != nil {
+					return nil, err
+				}
+
+				lines = append(lines, included...)
+				mergeSeenKeys(seenKeys, included, opts)
+				continue
+			}
+
+			if currentSection == "" {
+				return nil, ErrorInvalidFormat
+			}
+
+			foldedSection := foldSection(opts, currentSection)
+			foldedKey := foldKey(opts, key)
+			if seenKeys[foldedSection][foldedKey] && !opts.AllowDuplicateKeys {
+				return nil, ErrorRedefiningKey
+			}
+
+			seenKeys[foldedSection][foldedKey] = true
+			lines = append(lines, line{kind: lineKeyValue, raw: raw, section: currentSection, key: key, value: value})
+
+		default:
+			return nil, ErrorInvalidFormat
+		}
+	}
+
+	return lines, nil
+}
+
+// resolveInclude reads and parses the file target, resolved relative to
+// baseDir when it isn't already absolute.
+func (p *INIParser) resolveInclude(target, baseDir string, opts LoadOptions, visiting map[string]bool) ([]line, error) {
+	path := target
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(baseDir, path)
+	}
+
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return nil, ErrorIncludeNotFound
+	}
+
+	if visiting[abs] {
+		return nil, ErrorIncludeCycle
+	}
+
+	content, err := os.ReadFile(abs)
+	if err != nil {
+		return nil, ErrorIncludeNotFound
+	}
+
+	visiting[abs] = true
+	defer delete(visiting, abs)
+
+	return p.parseDocument(string(content), opts, filepath.Dir(abs), visiting)
+}
+
+// mergeSeenKeys folds the key-value lines an include contributed into
+// seenKeys, so that a key the including file repeats afterwards is
+// still caught as a redefinition. Sections and keys are folded the same
+// way parseDocument folds them under opts.Insensitive.
+func mergeSeenKeys(seenKeys map[string]map[string]bool, included []line, opts LoadOptions) {
+	for _, l := range included {
+		if l.kind != lineKeyValue {
+			continue
+		}
+
+		section := foldSection(opts, l.section)
+
+		if _, ok := seenKeys[section]; !ok {
+			seenKeys[section] = map[string]bool{}
+		}
+
+		seenKeys[section][foldKey(opts, l.key)] = true
+	}
+}
@@ -0,0 +1,278 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestLoadFromFileInclude(t *testing.T) {
+	dir := t.TempDir()
+
+	leaf := "[database]\nhost = localhost"
+	if err := os.WriteFile(filepath.Join(dir, "leaf.ini"), []byte(leaf), 0644); err != nil {
+		t.Fatalf("error creating leaf file: %v", err)
+	}
+
+	mid := "!include leaf.ini\n\n[server]\ninclude = leaf.ini\nport = 8080"
+	if err := os.WriteFile(filepath.Join(dir, "mid.ini"), []byte(mid), 0644); err != nil {
+		t.Fatalf("error creating mid file: %v", err)
+	}
+
+	root := "!include mid.ini\n\n[root]\nname = app"
+	rootPath := filepath.Join(dir, "root.ini")
+	if err := os.WriteFile(rootPath, []byte(root), 0644); err != nil {
+		t.Fatalf("error creating root file: %v", err)
+	}
+
+	ini := NewINIParser()
+	if err := ini.LoadFromFile(rootPath); err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+
+	got, err := ini.Get("database", "host")
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+
+	if got != "localhost" {
+		t.Errorf("expected localhost, got %v", got)
+	}
+
+	got, err = ini.Get("root", "name")
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+
+	if got != "app" {
+		t.Errorf("expected app, got %v", got)
+	}
+}
+
+func TestLoadFromFileIncludeMissingTarget(t *testing.T) {
+	dir := t.TempDir()
+
+	data := "!include missing.ini"
+	path := filepath.Join(dir, "root.ini")
+	if err := os.WriteFile(path, []byte(data), 0644); err != nil {
+		t.Fatalf("error creating file: %v", err)
+	}
+
+	ini := NewINIParser()
+
+	err := ini.LoadFromFile(path)
+	if err != ErrorIncludeNotFound {
+		t.Errorf("expected ErrorIncludeNotFound, got %v", err)
+	}
+}
+
+func TestLoadFromFileIncludeCycle(t *testing.T) {
+	dir := t.TempDir()
+
+	aPath := filepath.Join(dir, "a.ini")
+	bPath := filepath.Join(dir, "b.ini")
+
+	if err := os.WriteFile(aPath, []byte("!include b.ini"), 0644); err != nil {
+		t.Fatalf("error creating a.ini: %v", err)
+	}
+
+	if err := os.WriteFile(bPath, []byte("!include a.ini"), 0644); err != nil {
+		t.Fatalf("error creating b.ini: %v", err)
+	}
+
+	ini := NewINIParser()
+
+	err := ini.LoadFromFile(aPath)
+	if err != ErrorIncludeCycle {
+		t.Errorf("expected ErrorIncludeCycle, got %v", err)
+	}
+}
+
+func TestMerge(t *testing.T) {
+	base := NewINIParser()
+	if err := base.LoadFromString("[server]\nhost = localhost\nport = 8080"); err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+
+	override := NewINIParser()
+	if err := override.LoadFromString("[server]\nport = 9090\ntimeout = 30"); err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+
+	merged := NewINIParser()
+	if err := merged.LoadFromString("[server]\nhost = localhost\nport = 8080"); err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+
+	if err := merged.Merge(override, MergeOverride); err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+
+	got, err := merged.Get("server", "port")
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+
+	if got != "9090" {
+		t.Errorf("expected 9090, got %v", got)
+	}
+
+	got, err = merged.Get("server", "timeout")
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+
+	if got != "30" {
+		t.Errorf("expected 30, got %v", got)
+	}
+}
+
+func TestMergeKeep(t *testing.T) {
+	base := NewINIParser()
+	if err := base.LoadFromString("[server]\nport = 8080"); err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+
+	override := NewINIParser()
+	if err := override.LoadFromString("[server]\nport = 9090"); err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+
+	if err := base.Merge(override, MergeKeep); err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+
+	got, err := base.Get("server", "port")
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+
+	if got != "8080" {
+		t.Errorf("expected 8080 to be kept, got %v", got)
+	}
+}
+
+func TestMergeError(t *testing.T) {
+	base := NewINIParser()
+	if err := base.LoadFromString("[server]\nport = 8080"); err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+
+	override := NewINIParser()
+	if err := override.LoadFromString("[server]\nport = 9090"); err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+
+	err := base.Merge(override, MergeError)
+	if err != ErrorRedefiningKey {
+		t.Errorf("expected ErrorRedefiningKey, got %v", err)
+	}
+}
+
+func TestMergeErrorDoesNotPartiallyApply(t *testing.T) {
+	base := NewINIParser()
+	if err := base.LoadFromString("[server]\nhost = localhost\nport = 8080"); err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+
+	override := NewINIParser()
+	if err := override.LoadFromString("[server]\nhost = localhost\nport = 9090\nnewkey = value"); err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+
+	err := base.Merge(override, MergeError)
+	if err != ErrorRedefiningKey {
+		t.Fatalf("expected ErrorRedefiningKey, got %v", err)
+	}
+
+	if _, err := base.Get("server", "newkey"); err != ErrorKeyName {
+		t.Errorf("expected newkey to not be applied after a failed merge, got %v (err=%v)", "newkey", err)
+	}
+}
+
+func TestMergeErrorIgnoresChildSectionInheritance(t *testing.T) {
+	base := NewINIParser()
+	if err := base.LoadFromStringWithOptions("[server]\ntimeout = 30", LoadOptions{ChildSectionDelimiter: "."}); err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+
+	override := NewINIParser()
+	if err := override.LoadFromStringWithOptions("[server.db]\ntimeout = 60", LoadOptions{ChildSectionDelimiter: "."}); err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+
+	if err := base.Merge(override, MergeError); err != nil {
+		t.Fatalf("expected no error, since server.db does not itself define timeout: %v", err)
+	}
+
+	got, err := base.Get("server.db", "timeout")
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+
+	if got != "60" {
+		t.Errorf("expected 60, got %v", got)
+	}
+}
+
+func TestMergeKeepIgnoresChildSectionInheritance(t *testing.T) {
+	base := NewINIParser()
+	if err := base.LoadFromStringWithOptions("[server]\ntimeout = 30", LoadOptions{ChildSectionDelimiter: "."}); err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+
+	override := NewINIParser()
+	if err := override.LoadFromStringWithOptions("[server.db]\ntimeout = 60", LoadOptions{ChildSectionDelimiter: "."}); err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+
+	if err := base.Merge(override, MergeKeep); err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+
+	got, err := base.Get("server.db", "timeout")
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+
+	if got != "60" {
+		t.Errorf("expected server.db's own timeout 60 to be applied, not skipped via inherited value, got %v", got)
+	}
+}
+
+func TestMergeConcurrentCrossDoesNotDeadlock(t *testing.T) {
+	a := NewINIParser()
+	if err := a.LoadFromString("[server]\nport = 8080"); err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+
+	b := NewINIParser()
+	if err := b.LoadFromString("[server]\nport = 9090"); err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+
+	done := make(chan struct{})
+
+	go func() {
+		for i := 0; i < 1000; i++ {
+			a.Merge(b, MergeOverride)
+		}
+		done <- struct{}{}
+	}()
+
+	go func() {
+		for i := 0; i < 1000; i++ {
+			b.Merge(a, MergeOverride)
+		}
+		done <- struct{}{}
+	}()
+
+	for i := 0; i < 2; i++ {
+		select {
+		case <-done:
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out: a.Merge(b) and b.Merge(a) deadlocked")
+		}
+	}
+}
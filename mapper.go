@@ -0,0 +1,281 @@
+package main
+
+import (
+	"errors"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+var (
+	ErrorInvalidMapTarget     = errors.New("map target must be a non-nil pointer to a struct")
+	ErrorUnsupportedFieldType = errors.New("unsupported field type for struct mapping")
+)
+
+// NameMapper converts a struct field name into the section or key name
+// used to look it up in the ini data.
+type NameMapper func(string) string
+
+// TitleUnderscore is a NameMapper that converts names such as
+// "HTTPPort" into "http_port".
+var TitleUnderscore NameMapper = func(raw string) string {
+	var b strings.Builder
+
+	runes := []rune(raw)
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			if i > 0 && (!unicode.IsUpper(runes[i-1]) || (i+1 < len(runes) && !unicode.IsUpper(runes[i+1]))) {
+				b.WriteRune('_')
+			}
+
+			b.WriteRune(unicode.ToLower(r))
+			continue
+		}
+
+		b.WriteRune(r)
+	}
+
+	return strings.Trim(strings.ReplaceAll(b.String(), "__", "_"), "_")
+}
+
+// tagName parses an `ini:"name,omitempty"` struct tag and resolves the
+// effective name to use, falling back to mapper(field) or the raw field
+// name when no tag or mapper is set.
+func (p *INIParser) tagName(field reflect.StructField) (name string, omitempty bool) {
+	tag := field.Tag.Get("ini")
+	parts := strings.Split(tag, ",")
+
+	name = parts[0]
+	for _, opt := range parts[1:] {
+		if opt == "omitempty" {
+			omitempty = true
+		}
+	}
+
+	if name == "" {
+		if p.NameMapper != nil {
+			name = p.NameMapper(field.Name)
+		} else {
+			name = field.Name
+		}
+	}
+
+	return name, omitempty
+}
+
+// MapTo walks the exported fields of v, a pointer to a struct, and
+// populates them from the parser's sections. Each top-level field maps
+// to a section (overridable via an `ini:"name"` tag) and each of its
+// fields maps to a key within that section.
+func (p *INIParser) MapTo(v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return ErrorInvalidMapTarget
+	}
+
+	sv := rv.Elem()
+	st := sv.Type()
+	data := p.GetSections()
+
+	for i := 0; i < st.NumField(); i++ {
+		field := st.Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+
+		sectionName, _ := p.tagName(field)
+
+		section, ok := data[sectionName]
+		if !ok {
+			continue
+		}
+
+		sectionVal := sv.Field(i)
+		if sectionVal.Kind() != reflect.Struct {
+			return ErrorUnsupportedFieldType
+		}
+
+		sectionType := sectionVal.Type()
+
+		for j := 0; j < sectionType.NumField(); j++ {
+			keyField := sectionType.Field(j)
+			if keyField.PkgPath != "" {
+				continue
+			}
+
+			keyName, _ := p.tagName(keyField)
+
+			raw, ok := section[keyName]
+			if !ok {
+				continue
+			}
+
+			if err := setField(sectionVal.Field(j), raw); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// ReflectFrom performs the inverse of MapTo: it populates the parser's
+// sections from the exported fields of v, a pointer to a struct, so the
+// result can be written out with SaveToFile.
+func (p *INIParser) ReflectFrom(v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Ptr {
+		rv = rv.Elem()
+	}
+
+	if rv.Kind() != reflect.Struct {
+		return ErrorInvalidMapTarget
+	}
+
+	st := rv.Type()
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.lines = nil
+
+	for i := 0; i < st.NumField(); i++ {
+		field := st.Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+
+		sectionName, _ := p.tagName(field)
+
+		sectionVal := rv.Field(i)
+		if sectionVal.Kind() != reflect.Struct {
+			return ErrorUnsupportedFieldType
+		}
+
+		sectionType := sectionVal.Type()
+
+		for j := 0; j < sectionType.NumField(); j++ {
+			keyField := sectionType.Field(j)
+			if keyField.PkgPath != "" {
+				continue
+			}
+
+			keyName, omitempty := p.tagName(keyField)
+			keyVal := sectionVal.Field(j)
+
+			if omitempty && keyVal.IsZero() {
+				continue
+			}
+
+			str, err := fieldToString(keyVal)
+			if err != nil {
+				return err
+			}
+
+			p.setLocked(sectionName, keyName, str)
+		}
+	}
+
+	return nil
+}
+
+// setField converts raw into field's type and assigns it.
+func setField(field reflect.Value, raw string) error {
+	switch field.Interface().(type) {
+	case time.Duration:
+		d, err := time.ParseDuration(raw)
+		if err != nil {
+			return err
+		}
+
+		field.Set(reflect.ValueOf(d))
+		return nil
+	case time.Time:
+		t, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			return err
+		}
+
+		field.Set(reflect.ValueOf(t))
+		return nil
+	}
+
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(raw)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return err
+		}
+
+		field.SetInt(n)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return err
+		}
+
+		field.SetBool(b)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return err
+		}
+
+		field.SetFloat(f)
+	case reflect.Slice:
+		if field.Type().Elem().Kind() != reflect.String {
+			return ErrorUnsupportedFieldType
+		}
+
+		var values []string
+		if raw != "" {
+			for _, part := range strings.Split(raw, ",") {
+				values = append(values, strings.TrimSpace(part))
+			}
+		}
+
+		field.Set(reflect.ValueOf(values))
+	default:
+		return ErrorUnsupportedFieldType
+	}
+
+	return nil
+}
+
+// fieldToString renders field back into its ini string representation.
+func fieldToString(field reflect.Value) (string, error) {
+	switch v := field.Interface().(type) {
+	case time.Duration:
+		return v.String(), nil
+	case time.Time:
+		return v.Format(time.RFC3339), nil
+	}
+
+	switch field.Kind() {
+	case reflect.String:
+		return field.String(), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(field.Int(), 10), nil
+	case reflect.Bool:
+		return strconv.FormatBool(field.Bool()), nil
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(field.Float(), 'f', -1, 64), nil
+	case reflect.Slice:
+		if field.Type().Elem().Kind() != reflect.String {
+			return "", ErrorUnsupportedFieldType
+		}
+
+		values := make([]string, field.Len())
+		for i := range values {
+			values[i] = field.Index(i).String()
+		}
+
+		return strings.Join(values, ","), nil
+	default:
+		return "", ErrorUnsupportedFieldType
+	}
+}
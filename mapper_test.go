@@ -0,0 +1,171 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+var mapperData = `
+[server]
+ip = 127.0.0.1
+port = 8080
+timeout = 5s
+enabled = true
+
+[http_port]
+value = 9090`
+
+type Server struct {
+	IP      string `ini:"ip"`
+	Port    int64  `ini:"port"`
+	Timeout time.Duration
+	Enabled bool
+}
+
+type HTTPPort struct {
+	Value int64 `ini:"value"`
+}
+
+type Config struct {
+	Server   Server
+	HTTPPort HTTPPort
+}
+
+func TestMapTo(t *testing.T) {
+	ini := NewINIParser()
+	ini.NameMapper = TitleUnderscore
+
+	err := ini.LoadFromString(mapperData)
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+
+	var cfg Config
+	if err := ini.MapTo(&cfg); err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+
+	if cfg.Server.IP != "127.0.0.1" {
+		t.Errorf("expected ip 127.0.0.1, got %v", cfg.Server.IP)
+	}
+
+	if cfg.Server.Port != 8080 {
+		t.Errorf("expected port 8080, got %v", cfg.Server.Port)
+	}
+
+	if cfg.Server.Timeout != 5*time.Second {
+		t.Errorf("expected timeout 5s, got %v", cfg.Server.Timeout)
+	}
+
+	if !cfg.Server.Enabled {
+		t.Errorf("expected enabled true, got %v", cfg.Server.Enabled)
+	}
+
+	if cfg.HTTPPort.Value != 9090 {
+		t.Errorf("expected http_port.value 9090, got %v", cfg.HTTPPort.Value)
+	}
+}
+
+func TestMapToInvalidTarget(t *testing.T) {
+	ini := NewINIParser()
+
+	err := ini.MapTo(Config{})
+	if err != ErrorInvalidMapTarget {
+		t.Errorf("expected ErrorInvalidMapTarget, got %v", err)
+	}
+}
+
+type NonStructSectionConfig struct {
+	Name string `ini:"name"`
+}
+
+func TestMapToNonStructSectionField(t *testing.T) {
+	ini := NewINIParser()
+
+	if err := ini.LoadFromString("[name]\nx = 1"); err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+
+	var cfg NonStructSectionConfig
+	err := ini.MapTo(&cfg)
+	if err != ErrorUnsupportedFieldType {
+		t.Errorf("expected ErrorUnsupportedFieldType, got %v", err)
+	}
+}
+
+func TestReflectFromNonStructSectionField(t *testing.T) {
+	ini := NewINIParser()
+
+	cfg := NonStructSectionConfig{Name: "app"}
+	err := ini.ReflectFrom(&cfg)
+	if err != ErrorUnsupportedFieldType {
+		t.Errorf("expected ErrorUnsupportedFieldType, got %v", err)
+	}
+}
+
+func TestReflectFrom(t *testing.T) {
+	cfg := Config{
+		Server: Server{
+			IP:      "10.0.0.1",
+			Port:    1234,
+			Timeout: 2 * time.Second,
+			Enabled: false,
+		},
+		HTTPPort: HTTPPort{Value: 80},
+	}
+
+	ini := NewINIParser()
+	ini.NameMapper = TitleUnderscore
+
+	if err := ini.ReflectFrom(&cfg); err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+
+	got, err := ini.Get("server", "ip")
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+
+	if got != "10.0.0.1" {
+		t.Errorf("expected ip 10.0.0.1, got %v", got)
+	}
+
+	got, err = ini.Get("http_port", "value")
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+
+	if got != "80" {
+		t.Errorf("expected value 80, got %v", got)
+	}
+}
+
+func TestReflectFromHoldsLockForWholeCall(t *testing.T) {
+	cfg := Config{
+		Server: Server{IP: "10.0.0.1", Port: 1234},
+	}
+
+	ini := NewINIParser()
+	if err := ini.ReflectFrom(&cfg); err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+		for i := 0; i < 2000; i++ {
+			if err := ini.ReflectFrom(&cfg); err != nil {
+				t.Error(err)
+			}
+		}
+	}()
+
+	for i := 0; i < 2000; i++ {
+		if names := ini.GetSectionNames(); len(names) == 0 {
+			t.Fatal("observed parser with zero sections mid-ReflectFrom")
+		}
+	}
+
+	<-done
+}
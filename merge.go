@@ -0,0 +1,66 @@
+package main
+
+import "unsafe"
+
+// MergeStrategy controls how Merge resolves a key that is defined in
+// both parsers.
+type MergeStrategy int
+
+const (
+	// MergeOverride replaces the receiver's value with other's.
+	MergeOverride MergeStrategy = iota
+	// MergeKeep leaves the receiver's existing value untouched.
+	MergeKeep
+	// MergeError fails with ErrorRedefiningKey instead of merging.
+	MergeError
+)
+
+// Merge layers other's keys onto p, following strategy for any key
+// that already exists in p. This is the common pattern for composing a
+// base config with environment-specific overrides.
+//
+// Locks are acquired in a fixed order based on the parsers' addresses,
+// not call order, so that a concurrent p.Merge(other, ...) and
+// other.Merge(p, ...) can't deadlock on each other's mutex.
+func (p *INIParser) Merge(other *INIParser, strategy MergeStrategy) error {
+	if p == other {
+		return nil
+	}
+
+	first, second := p, other
+	if uintptr(unsafe.Pointer(p)) > uintptr(unsafe.Pointer(other)) {
+		first, second = other, p
+	}
+
+	first.mu.Lock()
+	defer first.mu.Unlock()
+
+	second.mu.Lock()
+	defer second.mu.Unlock()
+
+	if strategy == MergeError {
+		for _, l := range other.lines {
+			if l.kind != lineKeyValue {
+				continue
+			}
+
+			if p.hasOwnKey(l.section, l.key) {
+				return ErrorRedefiningKey
+			}
+		}
+	}
+
+	for _, l := range other.lines {
+		if l.kind != lineKeyValue {
+			continue
+		}
+
+		if strategy == MergeKeep && p.hasOwnKey(l.section, l.key) {
+			continue
+		}
+
+		p.setLocked(l.section, l.key, l.value)
+	}
+
+	return nil
+}
@@ -0,0 +1,164 @@
+package main
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+)
+
+// ErrorInterpolationCycle is returned by Get when resolving a
+// "${section:key}"/"%(key)s" reference would recurse back into itself.
+var ErrorInterpolationCycle = errors.New("interpolation cycle detected")
+
+// LoadOptions controls how LoadFromStringWithOptions/LoadFromFileWithOptions
+// parse a document and how the resulting parser resolves lookups.
+type LoadOptions struct {
+	// AllowDuplicateKeys collects repeated keys within a section instead
+	// of failing with ErrorRedefiningKey. Use GetValues to read them all.
+	AllowDuplicateKeys bool
+
+	// Insensitive makes section and key lookups case-insensitive.
+	Insensitive bool
+
+	// ChildSectionDelimiter, when set, makes a section name such as
+	// "server.db" a child of "server": a key missing from "server.db"
+	// falls back to the value defined in "server".
+	ChildSectionDelimiter string
+
+	// DefaultSection names the section that keys appearing before any
+	// "[section]" header are assigned to, instead of LoadFromString
+	// rejecting them with ErrorInvalidFormat.
+	DefaultSection string
+
+	// Interpolation expands "${section:key}" and "%(key)s" references
+	// found in values when they are read via Get.
+	Interpolation bool
+}
+
+var (
+	sectionKeyRefPattern = regexp.MustCompile(`\$\{([^:}]+):([^}]+)\}`)
+	localKeyRefPattern   = regexp.MustCompile(`%\(([^)]+)\)s`)
+)
+
+// sectionEqual compares two section names honoring the Insensitive option.
+func (p *INIParser) sectionEqual(a, b string) bool {
+	if p.options.Insensitive {
+		return strings.EqualFold(a, b)
+	}
+
+	return a == b
+}
+
+// keyEqual compares two key names honoring the Insensitive option.
+func (p *INIParser) keyEqual(a, b string) bool {
+	if p.options.Insensitive {
+		return strings.EqualFold(a, b)
+	}
+
+	return a == b
+}
+
+// foldKey returns the form of key used to detect redefinitions while
+// parsing: lower-cased under opts.Insensitive so "Host" and "host" in
+// the same section are recognized as the same key, verbatim otherwise.
+func foldKey(opts LoadOptions, key string) string {
+	if opts.Insensitive {
+		return strings.ToLower(key)
+	}
+
+	return key
+}
+
+// foldSection returns the form of section used to detect redefinitions
+// while parsing: lower-cased under opts.Insensitive so "[Server]" and
+// "[server]" are recognized as the same section, verbatim otherwise.
+func foldSection(opts LoadOptions, section string) string {
+	if opts.Insensitive {
+		return strings.ToLower(section)
+	}
+
+	return section
+}
+
+// parentSection reports the section section inherits defaults from
+// under ChildSectionDelimiter, e.g. "server" for "server.db".
+func (p *INIParser) parentSection(section string) (string, bool) {
+	delim := p.options.ChildSectionDelimiter
+	if delim == "" {
+		return "", false
+	}
+
+	idx := strings.LastIndex(section, delim)
+	if idx < 0 {
+		return "", false
+	}
+
+	return section[:idx], true
+}
+
+// interpolate expands "${section:key}" and "%(key)s" references found
+// in value, resolving "%(key)s" against section. visiting tracks the
+// section:key pairs currently being resolved so that a reference cycle
+// is reported as ErrorInterpolationCycle instead of recursing forever.
+func (p *INIParser) interpolate(section, value string, visiting map[string]bool) (string, error) {
+	var err error
+
+	result := sectionKeyRefPattern.ReplaceAllStringFunc(value, func(match string) string {
+		if err != nil {
+			return match
+		}
+
+		groups := sectionKeyRefPattern.FindStringSubmatch(match)
+
+		resolved, e := p.resolveRef(groups[1], groups[2], visiting)
+		if e != nil {
+			err = e
+			return match
+		}
+
+		return resolved
+	})
+	if err != nil {
+		return "", err
+	}
+
+	result = localKeyRefPattern.ReplaceAllStringFunc(result, func(match string) string {
+		if err != nil {
+			return match
+		}
+
+		groups := localKeyRefPattern.FindStringSubmatch(match)
+
+		resolved, e := p.resolveRef(section, groups[1], visiting)
+		if e != nil {
+			err = e
+			return match
+		}
+
+		return resolved
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return result, nil
+}
+
+// resolveRef resolves a single section/key reference, recursively
+// interpolating its own value.
+func (p *INIParser) resolveRef(section, key string, visiting map[string]bool) (string, error) {
+	ref := section + ":" + key
+	if visiting[ref] {
+		return "", ErrorInterpolationCycle
+	}
+
+	visiting[ref] = true
+	defer delete(visiting, ref)
+
+	raw, err := p.rawGet(section, key)
+	if err != nil {
+		return "", err
+	}
+
+	return p.interpolate(section, raw, visiting)
+}
@@ -0,0 +1,243 @@
+package main
+
+import "testing"
+
+var duplicateKeyData = `
+[server]
+host = 127.0.0.1
+host = 127.0.1.1`
+
+func TestLoadFromStringWithOptionsAllowDuplicateKeys(t *testing.T) {
+	ini := NewINIParser()
+
+	err := ini.LoadFromStringWithOptions(duplicateKeyData, LoadOptions{AllowDuplicateKeys: true})
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+
+	got, err := ini.GetValues("server", "host")
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+
+	want := []string{"127.0.0.1", "127.0.1.1"}
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+
+	err = ini.LoadFromStringWithOptions(duplicateKeyData, LoadOptions{})
+	if err != ErrorRedefiningKey {
+		t.Errorf("expected ErrorRedefiningKey without AllowDuplicateKeys, got %v", err)
+	}
+}
+
+var mixedCaseData = `
+[Server]
+Host = 127.0.0.1`
+
+func TestLoadFromStringWithOptionsInsensitive(t *testing.T) {
+	ini := NewINIParser()
+
+	err := ini.LoadFromStringWithOptions(mixedCaseData, LoadOptions{Insensitive: true})
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+
+	got, err := ini.Get("server", "host")
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+
+	if got != "127.0.0.1" {
+		t.Errorf("expected 127.0.0.1, got %v", got)
+	}
+}
+
+var duplicateCaseSectionData = `
+[Server]
+host = localhost
+
+[server]
+port = 8080`
+
+func TestGetSectionsInsensitiveFoldsDifferentlyCasedHeaders(t *testing.T) {
+	ini := NewINIParser()
+
+	err := ini.LoadFromStringWithOptions(duplicateCaseSectionData, LoadOptions{Insensitive: true})
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+
+	sections := ini.GetSections()
+	if len(sections) != 1 {
+		t.Fatalf("expected 1 section, got %v", sections)
+	}
+
+	server, ok := sections["Server"]
+	if !ok {
+		t.Fatalf("expected section keyed on first-seen casing %q, got %v", "Server", sections)
+	}
+
+	if server["host"] != "localhost" || server["port"] != "8080" {
+		t.Errorf("expected merged keys from both headers, got %v", server)
+	}
+
+	names := ini.GetSectionNames()
+	if len(names) != 1 || names[0] != "Server" {
+		t.Errorf("expected [\"Server\"], got %v", names)
+	}
+}
+
+var duplicateCaseKeyData = `
+[server]
+Host = 1.2.3.4
+host = 5.6.7.8`
+
+func TestLoadFromStringWithOptionsInsensitiveRejectsDifferentlyCasedKey(t *testing.T) {
+	ini := NewINIParser()
+
+	err := ini.LoadFromStringWithOptions(duplicateCaseKeyData, LoadOptions{Insensitive: true})
+	if err != ErrorRedefiningKey {
+		t.Fatalf("expected ErrorRedefiningKey, got %v", err)
+	}
+
+	err = ini.LoadFromStringWithOptions(duplicateCaseKeyData, LoadOptions{Insensitive: true, AllowDuplicateKeys: true})
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+
+	sections := ini.GetSections()
+	server, ok := sections["server"]
+	if !ok {
+		t.Fatalf("expected section keyed on first-seen casing %q, got %v", "server", sections)
+	}
+
+	if len(server) != 1 {
+		t.Errorf("expected Host/host folded into a single key, got %v", server)
+	}
+
+	if server["Host"] != "5.6.7.8" {
+		t.Errorf("expected key keyed on first-seen casing %q with last value, got %v", "Host", server)
+	}
+}
+
+var duplicateCaseSectionKeyData = `
+[Server]
+host = 1
+
+[server]
+host = 2`
+
+func TestLoadFromStringWithOptionsInsensitiveRejectsDifferentlyCasedSectionKey(t *testing.T) {
+	ini := NewINIParser()
+
+	err := ini.LoadFromStringWithOptions(duplicateCaseSectionKeyData, LoadOptions{Insensitive: true})
+	if err != ErrorRedefiningKey {
+		t.Fatalf("expected ErrorRedefiningKey, got %v", err)
+	}
+}
+
+var childSectionData = `
+[server]
+timeout = 30
+
+[server.db]
+host = localhost`
+
+func TestLoadFromStringWithOptionsChildSectionDelimiter(t *testing.T) {
+	ini := NewINIParser()
+
+	err := ini.LoadFromStringWithOptions(childSectionData, LoadOptions{ChildSectionDelimiter: "."})
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+
+	got, err := ini.Get("server.db", "host")
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+
+	if got != "localhost" {
+		t.Errorf("expected localhost, got %v", got)
+	}
+
+	got, err = ini.Get("server.db", "timeout")
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+
+	if got != "30" {
+		t.Errorf("expected inherited timeout 30, got %v", got)
+	}
+}
+
+var defaultSectionData = `
+timeout = 30
+[server]
+host = localhost`
+
+func TestLoadFromStringWithOptionsDefaultSection(t *testing.T) {
+	ini := NewINIParser()
+
+	err := ini.LoadFromStringWithOptions(defaultSectionData, LoadOptions{DefaultSection: "DEFAULT"})
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+
+	got, err := ini.Get("DEFAULT", "timeout")
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+
+	if got != "30" {
+		t.Errorf("expected 30, got %v", got)
+	}
+
+	err = ini.LoadFromStringWithOptions(defaultSectionData, LoadOptions{})
+	if err != ErrorInvalidFormat {
+		t.Errorf("expected ErrorInvalidFormat without DefaultSection, got %v", err)
+	}
+}
+
+var interpolationData = `
+[server]
+host = localhost
+port = 8080
+address = %(host)s:${server:port}`
+
+func TestLoadFromStringWithOptionsInterpolation(t *testing.T) {
+	ini := NewINIParser()
+
+	err := ini.LoadFromStringWithOptions(interpolationData, LoadOptions{Interpolation: true})
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+
+	got, err := ini.Get("server", "address")
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+
+	if got != "localhost:8080" {
+		t.Errorf("expected localhost:8080, got %v", got)
+	}
+}
+
+var interpolationCycleData = `
+[server]
+a = ${server:b}
+b = ${server:a}`
+
+func TestLoadFromStringWithOptionsInterpolationCycle(t *testing.T) {
+	ini := NewINIParser()
+
+	err := ini.LoadFromStringWithOptions(interpolationCycleData, LoadOptions{Interpolation: true})
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+
+	_, err = ini.Get("server", "a")
+	if err != ErrorInterpolationCycle {
+		t.Errorf("expected ErrorInterpolationCycle, got %v", err)
+	}
+}
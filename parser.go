@@ -0,0 +1,426 @@
+// Package main implements a small INI file parser and serializer.
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// Data represents the parsed content of an INI file as a map of
+// section name to a map of key/value pairs.
+type Data map[string]map[string]string
+
+// lineKind identifies what a parsed line of an INI document represents.
+type lineKind int
+
+const (
+	lineBlank lineKind = iota
+	lineComment
+	lineSection
+	lineKeyValue
+)
+
+// line is one line of a parsed INI document. Blank lines and comments
+// are kept verbatim so that String reproduces the original layout.
+type line struct {
+	kind    lineKind
+	raw     string
+	section string
+	key     string
+	value   string
+}
+
+// INIParser parses, stores and serializes INI-formatted configuration.
+// Content is kept as an ordered sequence of lines so that comments,
+// blank lines and key ordering survive a load/save round-trip.
+type INIParser struct {
+	mu      sync.RWMutex
+	lines   []line
+	options LoadOptions
+
+	// NameMapper, when set, derives section and key names from struct
+	// field names for MapTo/ReflectFrom when no `ini` tag is present.
+	NameMapper NameMapper
+}
+
+var (
+	ErrorFileExtension    = errors.New("invalid file extension, expected .ini")
+	ErrorOpeningFile      = errors.New("error opening file")
+	ErrorInvalidFormat    = errors.New("invalid ini format")
+	ErrorInvalidKeyFormat = errors.New("invalid key format")
+	ErrorSectionNameEmpty = errors.New("section name cannot be empty")
+	ErrorRedefiningKey    = errors.New("key is already defined in this section")
+	ErrorSectionNotFound  = errors.New("section not found")
+	ErrorKeyName          = errors.New("key not found")
+)
+
+// NewINIParser creates an empty INIParser ready to load data into.
+func NewINIParser() *INIParser {
+	return &INIParser{}
+}
+
+// LoadFromFile reads the file at path, ensures it has a .ini extension
+// and loads its content into the parser using the default, strict
+// options.
+func (p *INIParser) LoadFromFile(path string) error {
+	return p.LoadFromFileWithOptions(path, LoadOptions{})
+}
+
+// LoadFromString parses data in INI format and replaces the parser's
+// current content with the result, using the default, strict options.
+// Comments (; and #) and blank lines are preserved so that String can
+// reproduce the original document.
+func (p *INIParser) LoadFromString(data string) error {
+	return p.LoadFromStringWithOptions(data, LoadOptions{})
+}
+
+// LoadFromFileWithOptions is LoadFromFile with pluggable LoadOptions.
+// Relative "!include" targets in the file are resolved against its
+// directory.
+func (p *INIParser) LoadFromFileWithOptions(path string, opts LoadOptions) error {
+	if filepath.Ext(path) != ".ini" {
+		return ErrorFileExtension
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return ErrorOpeningFile
+	}
+
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return ErrorOpeningFile
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	lines, err := p.parseDocument(string(content), opts, filepath.Dir(abs), map[string]bool{abs: true})
+	if err != nil {
+		return err
+	}
+
+	p.lines = lines
+	p.options = opts
+	return nil
+}
+
+// LoadFromStringWithOptions is LoadFromString with pluggable LoadOptions:
+// AllowDuplicateKeys collects repeated keys instead of rejecting them,
+// Insensitive folds case in section/key lookups, ChildSectionDelimiter
+// lets a section such as "server.db" inherit defaults from "server",
+// DefaultSection captures keys that appear before any section header,
+// and Interpolation expands "${section:key}"/"%(key)s" references at
+// Get-time. "!include path" directives are resolved relative to the
+// current working directory.
+func (p *INIParser) LoadFromStringWithOptions(data string, opts LoadOptions) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	lines, err := p.parseDocument(data, opts, ".", map[string]bool{})
+	if err != nil {
+		return err
+	}
+
+	p.lines = lines
+	p.options = opts
+	return nil
+}
+
+// currentOptions returns the LoadOptions the parser was most recently
+// loaded with, so that a reload (e.g. Watch's) can reuse them instead
+// of silently reverting to the zero-value defaults.
+func (p *INIParser) currentOptions() LoadOptions {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	return p.options
+}
+
+// GetSections returns the parser's parsed sections as a plain map,
+// discarding ordering and comments. Under Insensitive, section names
+// and keys that differ only in case are folded together under
+// whichever casing appeared first, matching the folding Get/rawGet
+// already apply.
+func (p *INIParser) GetSections() Data {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	data := Data{}
+	canonicalSections := map[string]string{}
+	canonicalKeys := map[string]map[string]string{}
+
+	for _, l := range p.lines {
+		if l.kind != lineKeyValue {
+			continue
+		}
+
+		section := p.canonicalSectionLocked(l.section, canonicalSections)
+
+		if _, ok := data[section]; !ok {
+			data[section] = map[string]string{}
+			canonicalKeys[section] = map[string]string{}
+		}
+
+		key := p.canonicalKeyLocked(l.key, canonicalKeys[section])
+		data[section][key] = l.value
+	}
+
+	return data
+}
+
+// GetSectionNames returns the names of all parsed sections, in the
+// order they first appear. Under Insensitive, section names that
+// differ only in case are folded into a single entry.
+func (p *INIParser) GetSectionNames() []string {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	seen := map[string]bool{}
+	canonical := map[string]string{}
+	var names []string
+
+	for _, l := range p.lines {
+		if l.kind != lineSection {
+			continue
+		}
+
+		section := p.canonicalSectionLocked(l.section, canonical)
+		if seen[section] {
+			continue
+		}
+
+		seen[section] = true
+		names = append(names, section)
+	}
+
+	return names
+}
+
+// canonicalSectionLocked folds section to the casing it was first seen
+// under in canonical when Insensitive is set, registering it if this is
+// the first occurrence. It must be called with p.mu held.
+func (p *INIParser) canonicalSectionLocked(section string, canonical map[string]string) string {
+	if !p.options.Insensitive {
+		return section
+	}
+
+	fold := strings.ToLower(section)
+	if first, ok := canonical[fold]; ok {
+		return first
+	}
+
+	canonical[fold] = section
+	return section
+}
+
+// canonicalKeyLocked folds key to the casing it was first seen under in
+// canonical when Insensitive is set, registering it if this is the
+// first occurrence. canonical is scoped to a single section, since keys
+// only need to be unique within it. It must be called with p.mu held.
+func (p *INIParser) canonicalKeyLocked(key string, canonical map[string]string) string {
+	if !p.options.Insensitive {
+		return key
+	}
+
+	fold := strings.ToLower(key)
+	if first, ok := canonical[fold]; ok {
+		return first
+	}
+
+	canonical[fold] = key
+	return key
+}
+
+// Get returns the value of key within section. When the parser was
+// loaded with Interpolation enabled, "${section:key}" and "%(key)s"
+// references in the value are expanded before it is returned.
+func (p *INIParser) Get(section, key string) (string, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	value, err := p.rawGet(section, key)
+	if err != nil {
+		return "", err
+	}
+
+	if !p.options.Interpolation {
+		return value, nil
+	}
+
+	return p.interpolate(section, value, map[string]bool{})
+}
+
+// rawGet looks up key within section without interpolating the result,
+// honoring the Insensitive and ChildSectionDelimiter options: a section
+// such as "server.db" falls back to "server" for keys it doesn't define
+// itself.
+func (p *INIParser) rawGet(section, key string) (string, error) {
+	sectionFound := false
+
+	for _, l := range p.lines {
+		if l.kind == lineSection && p.sectionEqual(l.section, section) {
+			sectionFound = true
+		}
+
+		if l.kind == lineKeyValue && p.sectionEqual(l.section, section) {
+			sectionFound = true
+
+			if p.keyEqual(l.key, key) {
+				return l.value, nil
+			}
+		}
+	}
+
+	if parent, ok := p.parentSection(section); ok {
+		if value, err := p.rawGet(parent, key); err == nil {
+			return value, nil
+		}
+	}
+
+	if !sectionFound {
+		return "", ErrorSectionNotFound
+	}
+
+	return "", ErrorKeyName
+}
+
+// hasOwnKey reports whether key is defined literally within section,
+// unlike rawGet it does not fall back to a ChildSectionDelimiter
+// parent, so it tells apart a section that merely inherits a key from
+// one that defines it itself.
+func (p *INIParser) hasOwnKey(section, key string) bool {
+	for _, l := range p.lines {
+		if l.kind == lineKeyValue && p.sectionEqual(l.section, section) && p.keyEqual(l.key, key) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// GetValues returns every value assigned to key within section, in the
+// order they were parsed. It is the way to retrieve keys that were
+// repeated in the source when the parser was loaded with
+// AllowDuplicateKeys.
+func (p *INIParser) GetValues(section, key string) ([]string, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	var values []string
+	sectionFound := false
+
+	for _, l := range p.lines {
+		if l.kind == lineSection && p.sectionEqual(l.section, section) {
+			sectionFound = true
+		}
+
+		if l.kind == lineKeyValue && p.sectionEqual(l.section, section) {
+			sectionFound = true
+
+			if p.keyEqual(l.key, key) {
+				values = append(values, l.value)
+			}
+		}
+	}
+
+	if !sectionFound {
+		return nil, ErrorSectionNotFound
+	}
+
+	if len(values) == 0 {
+		return nil, ErrorKeyName
+	}
+
+	return values, nil
+}
+
+// Set assigns value to key within section, creating either if they
+// don't already exist. New keys are appended to the end of their
+// section and new sections to the end of the document, preserving the
+// rest of the document's layout.
+func (p *INIParser) Set(section, key, value string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.setLocked(section, key, value)
+}
+
+// setLocked is Set's body, for callers that already hold p.mu.
+func (p *INIParser) setLocked(section, key, value string) {
+	for i := range p.lines {
+		l := &p.lines[i]
+		if l.kind == lineKeyValue && p.sectionEqual(l.section, section) && p.keyEqual(l.key, key) {
+			l.value = value
+			l.raw = fmt.Sprintf("%s = %s", l.key, value)
+			return
+		}
+	}
+
+	newLine := line{kind: lineKeyValue, section: section, key: key, value: value, raw: fmt.Sprintf("%s = %s", key, value)}
+
+	insertAt := -1
+	sectionExists := false
+
+	for i, l := range p.lines {
+		if l.kind == lineSection && p.sectionEqual(l.section, section) {
+			sectionExists = true
+			if i > insertAt {
+				insertAt = i
+			}
+		}
+
+		if l.kind == lineKeyValue && p.sectionEqual(l.section, section) && i > insertAt {
+			insertAt = i
+		}
+	}
+
+	if sectionExists {
+		p.lines = append(p.lines[:insertAt+1], append([]line{newLine}, p.lines[insertAt+1:]...)...)
+		return
+	}
+
+	if len(p.lines) > 0 {
+		p.lines = append(p.lines, line{kind: lineBlank, raw: ""})
+	}
+
+	p.lines = append(p.lines, line{kind: lineSection, section: section, raw: fmt.Sprintf("[%s]", section)})
+	p.lines = append(p.lines, newLine)
+}
+
+// String renders the parser's content back into INI format, preserving
+// comments, blank lines and key ordering from the original document.
+func (p *INIParser) String() string {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	return p.stringLocked()
+}
+
+// stringLocked is String's body, for callers that already hold p.mu.
+func (p *INIParser) stringLocked() string {
+	raws := make([]string, len(p.lines))
+	for i, l := range p.lines {
+		raws[i] = l.raw
+	}
+
+	return strings.Join(raws, "\n")
+}
+
+// SaveToFile writes the parser's current content to path, which must
+// have a .ini extension.
+func (p *INIParser) SaveToFile(path string) error {
+	if filepath.Ext(path) != ".ini" {
+		return ErrorFileExtension
+	}
+
+	p.mu.RLock()
+	content := p.stringLocked()
+	p.mu.RUnlock()
+
+	return os.WriteFile(path, []byte(content), 0644)
+}
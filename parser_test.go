@@ -98,7 +98,7 @@ func TestLoadFromFile(t *testing.T) {
 		t.Fatalf("Error: invalid file name")
 	}
 
-	got := ini.sections
+	got := ini.GetSections()
 
 	if !reflect.DeepEqual(got, want) {
 		t.Errorf("config does not match expected config.\nExpected: %+v\nActual: %+v", want, got)
@@ -144,7 +144,7 @@ func TestLoadFromString(t *testing.T) {
 	if err != nil {
 		t.Errorf("Error:%v", err)
 	}
-	got := ini.sections
+	got := ini.GetSections()
 
 	if !reflect.DeepEqual(got, want) {
 		t.Errorf("config does not match expected config.\nExpected: %+v\nActual: %+v", want, got)
@@ -296,21 +296,21 @@ func TestSet(t *testing.T) {
 
 	ini.Set("database", "port", "8000")
 
-	got := ini.sections["database"]["port"]
+	got, _ := ini.Get("database", "port")
 
 	if !(got == want) {
 		t.Errorf("setting value does not match expected value.\nExpected: %+v\nActual: %+v", want, got)
 	}
 
 	ini.Set("database", "portt", "8000")
-	got = ini.sections["database"]["portt"]
+	got, _ = ini.Get("database", "portt")
 
 	if !(got == want) {
 		t.Errorf("setting value does not match expected value.\nExpected: %+v\nActual: %+v", want, got)
 	}
 
 	ini.Set("databasee", "port", "8000")
-	got = ini.sections["databasee"]["port"]
+	got, _ = ini.Get("databasee", "port")
 
 	if !(got == want) {
 		t.Errorf("setting value does not match expected value.\nExpected: %+v\nActual: %+v", want, got)
@@ -334,12 +334,27 @@ func TestString(t *testing.T) {
 		t.Errorf("config does not match expected config.\nExpected: %+v\nActual: %+v", want, got)
 	}
 
-	// Compare the parsed config with the expected config
-	if strings.Contains(got, ";server section") {
+	// Comments now survive a load/save round-trip instead of being discarded.
+	if !strings.Contains(got, ";server section") {
 		t.Errorf("config does not match expected config.\nExpected: %+v\nActual: %+v", want, got)
 	}
 }
 
+func TestStringRoundTrip(t *testing.T) {
+	ini := NewINIParser()
+
+	err := ini.LoadFromString(validData)
+	if err != nil {
+		t.Errorf("Error:%v", err)
+	}
+
+	got := ini.String()
+
+	if got != validData {
+		t.Errorf("round-trip output does not match original.\nExpected: %q\nActual: %q", validData, got)
+	}
+}
+
 func TestSaveToFile(t *testing.T) {
 
 	ini := NewINIParser()
@@ -0,0 +1,134 @@
+package main
+
+import (
+	"sync"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// Event describes a single key whose value changed between two
+// snapshots of a watched file.
+type Event struct {
+	Section string
+	Key     string
+	Old     string
+	New     string
+}
+
+// Snapshot returns a deep copy of the parser's current sections. Unlike
+// mutating the map returned by GetSections, mutating the result of
+// Snapshot can never race with concurrent Set/LoadFromFile calls on the
+// parser, since neither shares any underlying storage with it.
+func (p *INIParser) Snapshot() Data {
+	return p.GetSections()
+}
+
+// Watch reparses path every time it changes on disk and emits one
+// Event per key whose value differs from the previous snapshot. The
+// returned channel is closed if the underlying watcher fails.
+//
+// Callers must call the returned stop func once they're done watching.
+// Stopping unblocks and terminates the goroutine backing the channel,
+// even if it is currently blocked sending a diff, and releases the
+// underlying fsnotify watcher and its inotify descriptor. Calling stop
+// more than once is safe.
+func (p *INIParser) Watch(path string) (events <-chan Event, stop func(), err error) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := watcher.Add(path); err != nil {
+		watcher.Close()
+		return nil, nil, err
+	}
+
+	ch := make(chan Event)
+	done := make(chan struct{})
+	var stopOnce sync.Once
+
+	stop = func() {
+		stopOnce.Do(func() {
+			close(done)
+			watcher.Close()
+		})
+	}
+
+	go func() {
+		defer close(ch)
+
+		for {
+			select {
+			case <-done:
+				return
+
+			case ev, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+
+				if ev.Op&(fsnotify.Write|fsnotify.Create) == 0 {
+					continue
+				}
+
+				before := p.Snapshot()
+
+				if err := p.LoadFromFileWithOptions(path, p.currentOptions()); err != nil {
+					continue
+				}
+
+				for _, diff := range diffData(before, p.Snapshot()) {
+					select {
+					case ch <- diff:
+					case <-done:
+						return
+					}
+				}
+
+			case _, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+			}
+		}
+	}()
+
+	return ch, stop, nil
+}
+
+// diffData reports every section/key whose value differs between
+// before and after, including keys that were added or removed.
+func diffData(before, after Data) []Event {
+	sections := map[string]bool{}
+	for section := range before {
+		sections[section] = true
+	}
+
+	for section := range after {
+		sections[section] = true
+	}
+
+	var events []Event
+
+	for section := range sections {
+		keys := map[string]bool{}
+		for key := range before[section] {
+			keys[key] = true
+		}
+
+		for key := range after[section] {
+			keys[key] = true
+		}
+
+		for key := range keys {
+			oldValue := before[section][key]
+			newValue := after[section][key]
+
+			if oldValue != newValue {
+				events = append(events, Event{Section: section, Key: key, Old: oldValue, New: newValue})
+			}
+		}
+	}
+
+	return events
+}
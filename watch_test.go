@@ -0,0 +1,163 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestConcurrentSetGet(t *testing.T) {
+	ini := NewINIParser()
+
+	err := ini.LoadFromString(validData)
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < 50; i++ {
+		wg.Add(2)
+
+		go func(n int) {
+			defer wg.Done()
+			ini.Set("server", "port", "8080")
+		}(i)
+
+		go func(n int) {
+			defer wg.Done()
+			_, _ = ini.Get("server", "port")
+		}(i)
+	}
+
+	wg.Wait()
+}
+
+func TestSnapshotIsIndependentCopy(t *testing.T) {
+	ini := NewINIParser()
+
+	err := ini.LoadFromString(validData)
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+
+	snap := ini.Snapshot()
+	snap["server"]["port"] = "mutated"
+
+	got, err := ini.Get("server", "port")
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+
+	if got != "8080" {
+		t.Errorf("mutating a Snapshot affected the parser: got %v", got)
+	}
+}
+
+func TestWatch(t *testing.T) {
+	dir := t.TempDir()
+	filePath := filepath.Join(dir, "config.ini")
+
+	err := os.WriteFile(filePath, []byte(validData), 0644)
+	if err != nil {
+		t.Fatalf("error creating temp file: %v", err)
+	}
+
+	ini := NewINIParser()
+	if err := ini.LoadFromFile(filePath); err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+
+	events, stop, err := ini.Watch(filePath)
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	defer stop()
+
+	updated := "\n[server]\nip = 127.0.0.1\nport = 9090\n\n[database]\nhost = localhost\nport = 5432\nname = mydb"
+	if err := os.WriteFile(filePath, []byte(updated), 0644); err != nil {
+		t.Fatalf("error updating temp file: %v", err)
+	}
+
+	select {
+	case ev := <-events:
+		if ev.Section != "server" || ev.Key != "port" || ev.Old != "8080" || ev.New != "9090" {
+			t.Errorf("unexpected event: %+v", ev)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for watch event")
+	}
+}
+
+func TestWatchPreservesLoadOptionsAcrossReload(t *testing.T) {
+	dir := t.TempDir()
+	filePath := filepath.Join(dir, "config.ini")
+
+	if err := os.WriteFile(filePath, []byte(mixedCaseData), 0644); err != nil {
+		t.Fatalf("error creating temp file: %v", err)
+	}
+
+	ini := NewINIParser()
+	if err := ini.LoadFromFileWithOptions(filePath, LoadOptions{Insensitive: true}); err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+
+	events, stop, err := ini.Watch(filePath)
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	defer stop()
+
+	updated := "\n[Server]\nHost = 127.0.0.2"
+	if err := os.WriteFile(filePath, []byte(updated), 0644); err != nil {
+		t.Fatalf("error updating temp file: %v", err)
+	}
+
+	select {
+	case <-events:
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for watch event")
+	}
+
+	got, err := ini.Get("server", "host")
+	if err != nil {
+		t.Fatalf("Insensitive option was lost across reload: %v", err)
+	}
+
+	if got != "127.0.0.2" {
+		t.Errorf("expected 127.0.0.2, got %v", got)
+	}
+}
+
+func TestWatchStopUnblocksGoroutine(t *testing.T) {
+	dir := t.TempDir()
+	filePath := filepath.Join(dir, "config.ini")
+
+	if err := os.WriteFile(filePath, []byte(validData), 0644); err != nil {
+		t.Fatalf("error creating temp file: %v", err)
+	}
+
+	ini := NewINIParser()
+	if err := ini.LoadFromFile(filePath); err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+
+	events, stop, err := ini.Watch(filePath)
+	if err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+
+	stop()
+	stop() // must be safe to call more than once
+
+	select {
+	case _, ok := <-events:
+		if ok {
+			t.Errorf("expected events to be closed after stop")
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for events channel to close after stop")
+	}
+}